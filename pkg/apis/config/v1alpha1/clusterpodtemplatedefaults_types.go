@@ -0,0 +1,77 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope="Cluster",shortName=cptd
+
+// ClusterPodTemplateDefaults represents the cluster-level defaulting rules a cluster-admin
+// applies to every propagated workload's PodTemplateSpec at bind time, so that pod hardening
+// (tolerations, node selectors, pulled-image credentials, and so on) can be enforced without
+// editing every workload manifest.
+type ClusterPodTemplateDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec represents the specification of the desired behavior of ClusterPodTemplateDefaults.
+	// +required
+	Spec ClusterPodTemplateDefaultsSpec `json:"spec"`
+}
+
+// ClusterPodTemplateDefaultsSpec represents the specification of the desired behavior of
+// ClusterPodTemplateDefaults.
+type ClusterPodTemplateDefaultsSpec struct {
+	// Tolerations to be appended, if not already present, to every propagated PodTemplateSpec.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeSelector entries to be merged into every propagated PodTemplateSpec. Keys already set
+	// on the workload's PodTemplateSpec take precedence over these defaults.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// TopologySpreadConstraints to be appended, if not already present, to every propagated
+	// PodTemplateSpec.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// ImagePullSecrets to be appended, if not already present, to every propagated
+	// PodTemplateSpec, so that images can be pulled from a per-cluster private registry.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// SecurityContext to be applied to every propagated PodTemplateSpec that does not already
+	// set one.
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// PriorityClassName to be applied to every propagated PodTemplateSpec that does not already
+	// set one.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Env entries to be appended, if not already present by name, to every container and init
+	// container of every propagated PodTemplateSpec.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPodTemplateDefaultsList contains a list of ClusterPodTemplateDefaults.
+type ClusterPodTemplateDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items holds a list of ClusterPodTemplateDefaults.
+	Items []ClusterPodTemplateDefaults `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterPodTemplateDefaults{}, &ClusterPodTemplateDefaultsList{})
+}
@@ -0,0 +1,205 @@
+package dependencies
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	configv1alpha1 "github.com/karmada-io/karmada/pkg/apis/config/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/util/helper"
+)
+
+var (
+	appsv1DeploymentGVK          = appsv1.SchemeGroupVersion.WithKind("Deployment")
+	appsv1StatefulSetGVK         = appsv1.SchemeGroupVersion.WithKind("StatefulSet")
+	appsv1DaemonSetGVK           = appsv1.SchemeGroupVersion.WithKind("DaemonSet")
+	batchv1JobGVK                = batchv1.SchemeGroupVersion.WithKind("Job")
+	batchv1CronJobGVK            = batchv1.SchemeGroupVersion.WithKind("CronJob")
+	corev1PodGVK                 = corev1.SchemeGroupVersion.WithKind("Pod")
+	networkingv1IngressGVK       = networkingv1.SchemeGroupVersion.WithKind("Ingress")
+	autoscalingv2HPAGVK          = autoscalingv2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler")
+	networkingv1NetworkPolicyGVK = networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy")
+	corev1ServiceAccountGVK      = corev1.SchemeGroupVersion.WithKind("ServiceAccount")
+)
+
+// getPodTemplateDependencies handles every workload kind whose spec carries a corev1.PodTemplateSpec
+// at spec.template (Deployment, StatefulSet, DaemonSet, Job), by lowering it to a Pod the same way
+// the detector already does before calling helper.GetDependenciesFromPodTemplate.
+func getPodTemplateDependencies(object interface{}) ([]configv1alpha1.DependentObjectReference, error) {
+	var template *corev1.PodTemplateSpec
+	var namespace string
+
+	switch workload := object.(type) {
+	case *appsv1.Deployment:
+		template, namespace = &workload.Spec.Template, workload.Namespace
+	case *appsv1.StatefulSet:
+		template, namespace = &workload.Spec.Template, workload.Namespace
+	case *appsv1.DaemonSet:
+		template, namespace = &workload.Spec.Template, workload.Namespace
+	case *batchv1.Job:
+		template, namespace = &workload.Spec.Template, workload.Namespace
+	default:
+		return nil, fmt.Errorf("getPodTemplateDependencies: unsupported type %T", object)
+	}
+
+	pod := helper.GeneratePodFromTemplateAndNamespace(template, namespace)
+	return helper.GetDependenciesFromPodTemplate(pod)
+}
+
+// getCronJobDependencies descends into the nested job template before delegating to the
+// common pod-template extraction logic.
+func getCronJobDependencies(object interface{}) ([]configv1alpha1.DependentObjectReference, error) {
+	cronJob, ok := object.(*batchv1.CronJob)
+	if !ok {
+		return nil, fmt.Errorf("getCronJobDependencies: unsupported type %T", object)
+	}
+	template := cronJob.Spec.JobTemplate.Spec.Template
+	pod := helper.GeneratePodFromTemplateAndNamespace(&template, cronJob.Namespace)
+	return helper.GetDependenciesFromPodTemplate(pod)
+}
+
+func getPodDependencies(object interface{}) ([]configv1alpha1.DependentObjectReference, error) {
+	pod, ok := object.(*corev1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("getPodDependencies: unsupported type %T", object)
+	}
+	return helper.GetDependenciesFromPodTemplate(pod)
+}
+
+// getIngressDependencies reports the TLS secrets and the backend Services (including the
+// default backend) an Ingress relies on.
+func getIngressDependencies(object interface{}) ([]configv1alpha1.DependentObjectReference, error) {
+	ingress, ok := object.(*networkingv1.Ingress)
+	if !ok {
+		return nil, fmt.Errorf("getIngressDependencies: unsupported type %T", object)
+	}
+
+	var refs []configv1alpha1.DependentObjectReference
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+		refs = append(refs, configv1alpha1.DependentObjectReference{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  ingress.Namespace,
+			Name:       tls.SecretName,
+		})
+	}
+
+	addBackendService := func(backend *networkingv1.IngressBackend) {
+		if backend == nil || backend.Service == nil || backend.Service.Name == "" {
+			return
+		}
+		refs = append(refs, configv1alpha1.DependentObjectReference{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Namespace:  ingress.Namespace,
+			Name:       backend.Service.Name,
+		})
+	}
+
+	addBackendService(ingress.Spec.DefaultBackend)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			addBackendService(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+	return refs, nil
+}
+
+// getHPADependencies reports the workload a HorizontalPodAutoscaler scales.
+func getHPADependencies(object interface{}) ([]configv1alpha1.DependentObjectReference, error) {
+	hpa, ok := object.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		return nil, fmt.Errorf("getHPADependencies: unsupported type %T", object)
+	}
+	target := hpa.Spec.ScaleTargetRef
+	if target.Name == "" {
+		return nil, nil
+	}
+	return []configv1alpha1.DependentObjectReference{
+		{
+			APIVersion: target.APIVersion,
+			Kind:       target.Kind,
+			Namespace:  hpa.Namespace,
+			Name:       target.Name,
+		},
+	}, nil
+}
+
+// getNetworkPolicyDependencies reports the namespaces a NetworkPolicy's ingress/egress peers name
+// through a namespaceSelector matchLabels entry on kubernetes.io/metadata.name, so that namespace
+// keeps existing on the member cluster wherever the policy is propagated. Selectors on other
+// labels, or using matchExpressions, are not resolved to namespaces.
+func getNetworkPolicyDependencies(object interface{}) ([]configv1alpha1.DependentObjectReference, error) {
+	policy, ok := object.(*networkingv1.NetworkPolicy)
+	if !ok {
+		return nil, fmt.Errorf("getNetworkPolicyDependencies: unsupported type %T", object)
+	}
+
+	var refs []configv1alpha1.DependentObjectReference
+	addPeers := func(peers []networkingv1.NetworkPolicyPeer) {
+		for _, peer := range peers {
+			if peer.NamespaceSelector == nil {
+				continue
+			}
+			if name, ok := peer.NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"]; ok && name != "" {
+				refs = append(refs, configv1alpha1.DependentObjectReference{
+					APIVersion: "v1",
+					Kind:       "Namespace",
+					Name:       name,
+				})
+			}
+		}
+	}
+
+	for _, ingress := range policy.Spec.Ingress {
+		addPeers(ingress.From)
+	}
+	for _, egress := range policy.Spec.Egress {
+		addPeers(egress.To)
+	}
+	return refs, nil
+}
+
+// getServiceAccountDependencies reports the image pull secrets and the mountable secrets a
+// ServiceAccount references.
+func getServiceAccountDependencies(object interface{}) ([]configv1alpha1.DependentObjectReference, error) {
+	sa, ok := object.(*corev1.ServiceAccount)
+	if !ok {
+		return nil, fmt.Errorf("getServiceAccountDependencies: unsupported type %T", object)
+	}
+
+	var refs []configv1alpha1.DependentObjectReference
+	for _, ref := range sa.ImagePullSecrets {
+		if ref.Name == "" {
+			continue
+		}
+		refs = append(refs, configv1alpha1.DependentObjectReference{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  sa.Namespace,
+			Name:       ref.Name,
+		})
+	}
+	for _, ref := range sa.Secrets {
+		if ref.Name == "" {
+			continue
+		}
+		refs = append(refs, configv1alpha1.DependentObjectReference{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  sa.Namespace,
+			Name:       ref.Name,
+		})
+	}
+	return refs, nil
+}
@@ -0,0 +1,71 @@
+package dependencies
+
+import (
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDefaultRegistry_Dispatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		object    interface{}
+		wantKinds []string
+	}{
+		{
+			name: "HorizontalPodAutoscaler",
+			object: &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hpa"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Name:       "my-deploy",
+					},
+				},
+			},
+			wantKinds: []string{"Deployment"},
+		},
+		{
+			name: "Ingress",
+			object: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "ingress"},
+				Spec: networkingv1.IngressSpec{
+					TLS: []networkingv1.IngressTLS{{SecretName: "tls-secret"}},
+				},
+			},
+			wantKinds: []string{"Secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gvk := autoscalingv2HPAGVK
+			if tt.name == "Ingress" {
+				gvk = networkingv1IngressGVK
+			}
+			refs, err := DefaultRegistry.GetDependencies(gvk, tt.object)
+			if err != nil {
+				t.Fatalf("GetDependencies() error = %v", err)
+			}
+			if len(refs) != len(tt.wantKinds) {
+				t.Fatalf("GetDependencies() = %v, want %d refs", refs, len(tt.wantKinds))
+			}
+			for i, kind := range tt.wantKinds {
+				if refs[i].Kind != kind {
+					t.Errorf("refs[%d].Kind = %q, want %q", i, refs[i].Kind, kind)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistry_GetDependencies_UnregisteredGVK(t *testing.T) {
+	registry := NewRegistry()
+	_, err := registry.GetDependencies(corev1PodGVK, nil)
+	if err == nil {
+		t.Fatal("GetDependencies() error = nil, want an error for an unregistered GVK")
+	}
+}
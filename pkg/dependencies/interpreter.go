@@ -0,0 +1,98 @@
+// Package dependencies provides a pluggable registry of interpreters that discover the
+// dependent objects (ConfigMaps, Secrets, Services, and so on) a resource needs in order to
+// run correctly on a member cluster, so the propagation pipeline can bind those objects along
+// with it.
+package dependencies
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	configv1alpha1 "github.com/karmada-io/karmada/pkg/apis/config/v1alpha1"
+)
+
+// DependencyInterpreter discovers the dependencies of a resource of a specific GroupVersionKind.
+// Implementations are expected to be side-effect free and safe for concurrent use.
+type DependencyInterpreter interface {
+	// GetDependencies returns the set of objects the given resource depends on.
+	GetDependencies(object interface{}) ([]configv1alpha1.DependentObjectReference, error)
+}
+
+// DependencyInterpreterFunc is an adapter allowing the use of ordinary functions as
+// DependencyInterpreters.
+type DependencyInterpreterFunc func(object interface{}) ([]configv1alpha1.DependentObjectReference, error)
+
+// GetDependencies calls f(object).
+func (f DependencyInterpreterFunc) GetDependencies(object interface{}) ([]configv1alpha1.DependentObjectReference, error) {
+	return f(object)
+}
+
+// Registry keys DependencyInterpreters by the GroupVersionKind they handle, the same way
+// admission plugins are registered in kube-apiserver. A single process-wide Registry is
+// exposed as DefaultRegistry, but callers may also construct their own for testing.
+type Registry struct {
+	lock         sync.RWMutex
+	interpreters map[schema.GroupVersionKind]DependencyInterpreter
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		interpreters: make(map[schema.GroupVersionKind]DependencyInterpreter),
+	}
+}
+
+// Register associates interpreter with gvk, overwriting any interpreter previously registered
+// for the same GroupVersionKind. This allows callers to register interpreters for their own
+// CRDs, or to override a built-in interpreter.
+func (r *Registry) Register(gvk schema.GroupVersionKind, interpreter DependencyInterpreter) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.interpreters[gvk] = interpreter
+}
+
+// Get returns the interpreter registered for gvk, if any.
+func (r *Registry) Get(gvk schema.GroupVersionKind) (DependencyInterpreter, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	interpreter, ok := r.interpreters[gvk]
+	return interpreter, ok
+}
+
+// GetDependencies looks up the interpreter registered for gvk and invokes it. It returns an
+// error if no interpreter has been registered for gvk, so callers can distinguish "no
+// dependencies" from "kind not supported".
+func (r *Registry) GetDependencies(gvk schema.GroupVersionKind, object interface{}) ([]configv1alpha1.DependentObjectReference, error) {
+	interpreter, ok := r.Get(gvk)
+	if !ok {
+		return nil, fmt.Errorf("no dependency interpreter registered for %s", gvk.String())
+	}
+	return interpreter.GetDependencies(object)
+}
+
+// DefaultRegistry is the process-wide Registry used by the detector and dependencies-distributor
+// controllers. It is pre-populated with interpreters for the common workload and networking
+// kinds by RegisterDefaultInterpreters, which is called from this package's init.
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	RegisterDefaultInterpreters(DefaultRegistry)
+}
+
+// RegisterDefaultInterpreters registers the built-in interpreters for Deployment, StatefulSet,
+// DaemonSet, Job, CronJob, Pod, Ingress, HorizontalPodAutoscaler, NetworkPolicy and
+// ServiceAccount into r.
+func RegisterDefaultInterpreters(r *Registry) {
+	r.Register(appsv1DeploymentGVK, DependencyInterpreterFunc(getPodTemplateDependencies))
+	r.Register(appsv1StatefulSetGVK, DependencyInterpreterFunc(getPodTemplateDependencies))
+	r.Register(appsv1DaemonSetGVK, DependencyInterpreterFunc(getPodTemplateDependencies))
+	r.Register(batchv1JobGVK, DependencyInterpreterFunc(getPodTemplateDependencies))
+	r.Register(batchv1CronJobGVK, DependencyInterpreterFunc(getCronJobDependencies))
+	r.Register(corev1PodGVK, DependencyInterpreterFunc(getPodDependencies))
+	r.Register(networkingv1IngressGVK, DependencyInterpreterFunc(getIngressDependencies))
+	r.Register(autoscalingv2HPAGVK, DependencyInterpreterFunc(getHPADependencies))
+	r.Register(networkingv1NetworkPolicyGVK, DependencyInterpreterFunc(getNetworkPolicyDependencies))
+	r.Register(corev1ServiceAccountGVK, DependencyInterpreterFunc(getServiceAccountDependencies))
+}
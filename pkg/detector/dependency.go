@@ -0,0 +1,77 @@
+// Package detector discovers the dependent objects a resource needs propagated alongside it.
+package detector
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	configv1alpha1 "github.com/karmada-io/karmada/pkg/apis/config/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/dependencies"
+	"github.com/karmada-io/karmada/pkg/util/helper"
+)
+
+// GetDependencies discovers the dependent objects of obj, dispatching through
+// dependencies.DefaultRegistry by obj's GroupVersionKind instead of type-switching on Pod. Built-in
+// kinds are converted to their typed form before dispatch; a GVK with no typed representation in
+// client-go's scheme (any CRD) is passed to its interpreter as the raw *unstructured.Unstructured,
+// so a user-registered interpreter for a CRD can still run. If podTemplateDefaults is non-nil, it
+// is merged into obj's PodTemplateSpec (for the built-in workload kinds that carry one) before
+// dependency discovery runs, so that defaults-injected imagePullSecrets also participate in
+// dependency discovery. GetDependencies returns a nil slice and no error for kinds
+// with no registered DependencyInterpreter, since not every propagated resource has (or needs) one.
+func GetDependencies(obj *unstructured.Unstructured, podTemplateDefaults *configv1alpha1.ClusterPodTemplateDefaultsSpec) ([]configv1alpha1.DependentObjectReference, error) {
+	gvk := obj.GroupVersionKind()
+	if _, ok := dependencies.DefaultRegistry.Get(gvk); !ok {
+		return nil, nil
+	}
+
+	typed, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		// gvk isn't a kind client-go's built-in scheme knows how to construct, which is always
+		// true for a CRD. Hand the interpreter the unstructured object itself instead of failing,
+		// so a custom interpreter registered for this GVK (necessarily written against
+		// *unstructured.Unstructured, since it can never appear in the built-in scheme) still runs.
+		return dependencies.DefaultRegistry.GetDependencies(gvk, obj)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed); err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s/%s to a typed object: %w", gvk.Kind, obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	if podTemplateDefaults != nil {
+		applyPodTemplateDefaults(typed, podTemplateDefaults)
+	}
+
+	return dependencies.DefaultRegistry.GetDependencies(gvk, typed)
+}
+
+// applyPodTemplateDefaults merges podTemplateDefaults into typed's embedded PodTemplateSpec, for
+// the workload kinds dependencies.DefaultRegistry knows how to lower to a Pod. Kinds with no
+// PodTemplateSpec (Ingress, HorizontalPodAutoscaler, and so on) are left untouched.
+func applyPodTemplateDefaults(typed runtime.Object, podTemplateDefaults *configv1alpha1.ClusterPodTemplateDefaultsSpec) {
+	var template *corev1.PodTemplateSpec
+	switch workload := typed.(type) {
+	case *appsv1.Deployment:
+		template = &workload.Spec.Template
+	case *appsv1.StatefulSet:
+		template = &workload.Spec.Template
+	case *appsv1.DaemonSet:
+		template = &workload.Spec.Template
+	case *batchv1.Job:
+		template = &workload.Spec.Template
+	case *batchv1.CronJob:
+		template = &workload.Spec.JobTemplate.Spec.Template
+	case *corev1.Pod:
+		defaulted := helper.ApplyPodTemplateDefaults(&corev1.PodTemplateSpec{Spec: workload.Spec}, podTemplateDefaults)
+		workload.Spec = defaulted.Spec
+		return
+	default:
+		return
+	}
+	*template = *helper.ApplyPodTemplateDefaults(template, podTemplateDefaults)
+}
@@ -0,0 +1,143 @@
+package detector
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	configv1alpha1 "github.com/karmada-io/karmada/pkg/apis/config/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/dependencies"
+)
+
+func TestGetDependencies_DispatchesThroughRegistry(t *testing.T) {
+	deployment := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"namespace": "test",
+				"name":      "my-deploy",
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name": "main",
+								"envFrom": []interface{}{
+									map[string]interface{}{
+										"configMapRef": map[string]interface{}{"name": "my-config"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	refs, err := GetDependencies(deployment, nil)
+	if err != nil {
+		t.Fatalf("GetDependencies() error = %v", err)
+	}
+	if len(refs) != 1 || refs[0].Kind != "ConfigMap" || refs[0].Name != "my-config" {
+		t.Fatalf("GetDependencies() = %+v, want a single ConfigMap/my-config reference", refs)
+	}
+}
+
+func TestGetDependencies_UnregisteredKind(t *testing.T) {
+	namespace := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]interface{}{"name": "test"},
+		},
+	}
+
+	refs, err := GetDependencies(namespace, nil)
+	if err != nil {
+		t.Fatalf("GetDependencies() error = %v", err)
+	}
+	if refs != nil {
+		t.Fatalf("GetDependencies() = %v, want nil for a kind with no registered interpreter", refs)
+	}
+}
+
+func TestGetDependencies_AppliesPodTemplateDefaults(t *testing.T) {
+	deployment := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"namespace": "test",
+				"name":      "my-deploy",
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "main"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	defaults := &configv1alpha1.ClusterPodTemplateDefaultsSpec{
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-creds"}},
+	}
+
+	refs, err := GetDependencies(deployment, defaults)
+	if err != nil {
+		t.Fatalf("GetDependencies() error = %v", err)
+	}
+	if len(refs) != 1 || refs[0].Kind != "Secret" || refs[0].Name != "registry-creds" {
+		t.Fatalf("GetDependencies() = %+v, want the defaults-injected imagePullSecret to participate in dependency discovery", refs)
+	}
+}
+
+// TestGetDependencies_CustomCRDInterpreter proves a user-registered interpreter for a CRD GVK
+// (necessarily absent from client-go's built-in scheme) is still invoked, with the unstructured
+// object, instead of GetDependencies failing before dispatch.
+func TestGetDependencies_CustomCRDInterpreter(t *testing.T) {
+	widgetGVK := schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Widget"}
+
+	registry := dependencies.DefaultRegistry
+	var gotObject interface{}
+	registry.Register(widgetGVK, dependencies.DependencyInterpreterFunc(func(object interface{}) ([]configv1alpha1.DependentObjectReference, error) {
+		gotObject = object
+		u, ok := object.(*unstructured.Unstructured)
+		if !ok {
+			t.Fatalf("interpreter received %T, want *unstructured.Unstructured", object)
+		}
+		return []configv1alpha1.DependentObjectReference{
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: u.GetNamespace(), Name: "widget-config"},
+		}, nil
+	}))
+
+	widget := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "example.io/v1",
+			"kind":       "Widget",
+			"metadata": map[string]interface{}{
+				"namespace": "test",
+				"name":      "my-widget",
+			},
+		},
+	}
+
+	refs, err := GetDependencies(widget, nil)
+	if err != nil {
+		t.Fatalf("GetDependencies() error = %v", err)
+	}
+	if gotObject == nil {
+		t.Fatal("custom interpreter was never invoked")
+	}
+	if len(refs) != 1 || refs[0].Kind != "ConfigMap" || refs[0].Name != "widget-config" {
+		t.Fatalf("GetDependencies() = %+v, want a single ConfigMap/widget-config reference", refs)
+	}
+}
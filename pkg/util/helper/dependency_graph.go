@@ -0,0 +1,322 @@
+package helper
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configv1alpha1 "github.com/karmada-io/karmada/pkg/apis/config/v1alpha1"
+)
+
+// DependencyEdgeSource identifies the part of a pod spec that produced a DependencyEdge.
+type DependencyEdgeSource string
+
+const (
+	// DependencyEdgeSourceVolume means the edge was produced by a volume (ConfigMap/Secret/
+	// projected/CSI/PVC/ephemeral volume source).
+	DependencyEdgeSourceVolume DependencyEdgeSource = "Volume"
+	// DependencyEdgeSourceEnvFrom means the edge was produced by a container's envFrom.
+	DependencyEdgeSourceEnvFrom DependencyEdgeSource = "EnvFrom"
+	// DependencyEdgeSourceEnv means the edge was produced by a single env entry's valueFrom.
+	DependencyEdgeSourceEnv DependencyEdgeSource = "Env"
+	// DependencyEdgeSourceImagePullSecret means the edge was produced by spec.imagePullSecrets.
+	DependencyEdgeSourceImagePullSecret DependencyEdgeSource = "ImagePullSecret"
+	// DependencyEdgeSourceServiceAccount means the edge was produced by spec.serviceAccountName.
+	DependencyEdgeSourceServiceAccount DependencyEdgeSource = "ServiceAccount"
+)
+
+// DependencyEdge describes a single reference a pod spec makes to another object, together with
+// enough metadata to decide whether that reference should block propagation.
+type DependencyEdge struct {
+	// Reference is the object being depended on. It is the zero value for edges that do not
+	// reference a propagatable Kubernetes object (see Local).
+	Reference configv1alpha1.DependentObjectReference
+	// Container is the name of the (init) container that caused the reference, empty for
+	// pod-level or volume-only references.
+	Container string
+	// Volume is the name of the volume that caused the reference, empty if the reference did
+	// not come from a volume.
+	Volume string
+	// Source identifies which part of the pod spec produced this edge.
+	Source DependencyEdgeSource
+	// Optional is true if the reference tolerates its target being absent, mirroring
+	// configMapKeyRef.optional/secretKeyRef.optional/configMapRef.optional/secretRef.optional/
+	// the volume source's own Optional field.
+	Optional bool
+	// Local is true for edges that describe a local-only data source, such as a hostPath or
+	// downwardAPI volume, which never requires propagating a dependent object.
+	Local bool
+}
+
+// DependencyGraph is a structured record of every reference a pod spec makes, suitable for
+// strict validation and for explaining propagation decisions to users.
+type DependencyGraph struct {
+	Edges []DependencyEdge
+}
+
+// References returns the deduplicated set of DependentObjectReferences across all non-local
+// edges in the graph, collapsing edges that name the same object. This is what
+// GetDependenciesFromPodTemplate returns today.
+func (g *DependencyGraph) References() []configv1alpha1.DependentObjectReference {
+	seen := make(map[configv1alpha1.DependentObjectReference]struct{})
+	var refs []configv1alpha1.DependentObjectReference
+	for _, edge := range g.Edges {
+		if edge.Local {
+			continue
+		}
+		if _, ok := seen[edge.Reference]; ok {
+			continue
+		}
+		seen[edge.Reference] = struct{}{}
+		refs = append(refs, edge.Reference)
+	}
+	return refs
+}
+
+// DependencyMode controls how a DependencyGraph is resolved into the final set of references to
+// propagate.
+type DependencyMode string
+
+const (
+	// DependencyModeLenient propagates every referenced object regardless of whether the
+	// reference is marked optional. This is the historical, default behavior.
+	DependencyModeLenient DependencyMode = ""
+	// DependencyModeStrict causes Resolve to return an error naming the first required (that
+	// is, non-optional) reference whose target does not exist, instead of silently propagating
+	// an incomplete set of dependencies.
+	DependencyModeStrict DependencyMode = "Strict"
+	// DependencyModePermissiveOptional drops references marked optional: true from the result,
+	// to avoid over-propagating objects the workload can run without.
+	DependencyModePermissiveOptional DependencyMode = "PermissiveOptional"
+)
+
+// ExistenceChecker reports whether the object named by ref currently exists, so that Resolve can
+// enforce DependencyModeStrict. Implementations are expected to check the control plane cache.
+type ExistenceChecker func(ref configv1alpha1.DependentObjectReference) (bool, error)
+
+// DependencyOwner identifies the workload a DependencyGraph was extracted from, so that Resolve
+// can name it in the error it returns for DependencyModeStrict. The zero value is valid and
+// simply omits the owning-resource context from the error.
+type DependencyOwner struct {
+	// Kind is the owning resource's Kind, for example "Deployment".
+	Kind string
+	// Name is the owning resource's name.
+	Name string
+}
+
+// Resolve turns the graph into the final list of DependentObjectReferences to propagate,
+// applying mode. With DependencyModeStrict, checker must be non-nil; it is consulted for every
+// required (non-optional) reference, and the first missing one is returned as an error naming
+// owner (when owner is non-zero) so the caller (typically the dependencies-distributor
+// controller) can surface an actionable event such as "Secret foo/bar is required by Deployment X
+// but not found" instead of propagating silently.
+func (g *DependencyGraph) Resolve(mode DependencyMode, owner DependencyOwner, checker ExistenceChecker) ([]configv1alpha1.DependentObjectReference, error) {
+	switch mode {
+	case DependencyModePermissiveOptional:
+		return g.withoutOptional().References(), nil
+	case DependencyModeStrict:
+		for _, edge := range g.Edges {
+			if edge.Local || edge.Optional {
+				continue
+			}
+			exists, err := checker(edge.Reference)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check whether %s %s/%s exists: %w", edge.Reference.Kind, edge.Reference.Namespace, edge.Reference.Name, err)
+			}
+			if !exists {
+				if owner.Kind != "" || owner.Name != "" {
+					return nil, fmt.Errorf("%s %s/%s is required by %s %s but not found", edge.Reference.Kind, edge.Reference.Namespace, edge.Reference.Name, owner.Kind, owner.Name)
+				}
+				return nil, fmt.Errorf("%s %s/%s is required but not found", edge.Reference.Kind, edge.Reference.Namespace, edge.Reference.Name)
+			}
+		}
+		return g.References(), nil
+	default:
+		return g.References(), nil
+	}
+}
+
+func (g *DependencyGraph) withoutOptional() *DependencyGraph {
+	filtered := &DependencyGraph{}
+	for _, edge := range g.Edges {
+		if edge.Optional {
+			continue
+		}
+		filtered.Edges = append(filtered.Edges, edge)
+	}
+	return filtered
+}
+
+// GetDependencyGraphFromPodTemplate extracts a DependencyGraph from the given pod, recording the
+// container/volume that caused each reference and whether it is optional. This is the only
+// traversal of a pod's dependencies in this package: GetDependenciesFromPodTemplate calls
+// References() on the graph it returns, so there is a single source of truth for which objects a
+// pod depends on. Every ConfigMap/Secret reference is necessarily in the pod's own namespace,
+// since configMapRef/secretRef/volume sources cannot name another namespace; kinds whose
+// DependencyInterpreter can reference another namespace (for example NetworkPolicy's
+// namespaceSelector, see pkg/dependencies) report that at the interpreter level instead of
+// through this pod-scoped graph.
+func GetDependencyGraphFromPodTemplate(podObj *corev1.Pod) (*DependencyGraph, error) {
+	graph := &DependencyGraph{}
+
+	// ConfigMap/Secret edges are recorded with full container/volume/optional metadata below by
+	// visitPodVolumeEdges, visitPodEnvFromEdges and visitPodEnvEdges; only the remaining
+	// dependency kinds are added here.
+	for sa := range getServiceAccountNames(podObj) {
+		graph.Edges = append(graph.Edges, toEdge(configv1alpha1.DependentObjectReference{
+			APIVersion: "v1",
+			Kind:       "ServiceAccount",
+			Namespace:  podObj.Namespace,
+			Name:       sa,
+		}, DependencyEdgeSourceServiceAccount, "", "", false))
+	}
+	for i := range podObj.Spec.Volumes {
+		volume := podObj.Spec.Volumes[i]
+		single := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{volume}}}
+		for pvc := range getPVCNames(single) {
+			graph.Edges = append(graph.Edges, toEdge(configv1alpha1.DependentObjectReference{
+				APIVersion: "v1",
+				Kind:       "PersistentVolumeClaim",
+				Namespace:  podObj.Namespace,
+				Name:       pvc,
+			}, DependencyEdgeSourceVolume, "", volume.Name, false))
+		}
+	}
+	for name := range getImagePullSecretNames(podObj) {
+		graph.Edges = append(graph.Edges, secretEdge(podObj, "", "", DependencyEdgeSourceImagePullSecret, name, false))
+	}
+
+	visitPodVolumeEdges(podObj, &graph.Edges)
+	visitPodEnvFromEdges(podObj, &graph.Edges)
+	visitPodEnvEdges(podObj, &graph.Edges)
+
+	return graph, nil
+}
+
+// getImagePullSecretNames returns the secrets referenced by the pod's imagePullSecrets, so the
+// graph can label the resulting edges with DependencyEdgeSourceImagePullSecret.
+func getImagePullSecretNames(pod *corev1.Pod) sets.Set[string] {
+	result := sets.New[string]()
+	for _, ref := range pod.Spec.ImagePullSecrets {
+		if ref.Name != "" {
+			result.Insert(ref.Name)
+		}
+	}
+	return result
+}
+
+func configMapEdge(pod *corev1.Pod, container, volume string, source DependencyEdgeSource, name string, optional bool) DependencyEdge {
+	return toEdge(configv1alpha1.DependentObjectReference{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  pod.Namespace,
+		Name:       name,
+	}, source, container, volume, optional)
+}
+
+func secretEdge(pod *corev1.Pod, container, volume string, source DependencyEdgeSource, name string, optional bool) DependencyEdge {
+	return toEdge(configv1alpha1.DependentObjectReference{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Namespace:  pod.Namespace,
+		Name:       name,
+	}, source, container, volume, optional)
+}
+
+// toEdge wraps ref into a DependencyEdge.
+func toEdge(ref configv1alpha1.DependentObjectReference, source DependencyEdgeSource, container, volume string, optional bool) DependencyEdge {
+	return DependencyEdge{
+		Reference: ref,
+		Container: container,
+		Volume:    volume,
+		Source:    source,
+		Optional:  optional,
+	}
+}
+
+// visitPodVolumeEdges records edges for every volume source that references an object
+// (ConfigMap, Secret, projected sources, CSI NodePublishSecretRef), plus local-only edges for
+// hostPath and downwardAPI volumes so the graph documents every volume source even when it
+// carries nothing to propagate.
+func visitPodVolumeEdges(pod *corev1.Pod, edges *[]DependencyEdge) {
+	for i := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[i]
+		switch {
+		case volume.ConfigMap != nil:
+			*edges = append(*edges, configMapEdge(pod, "", volume.Name, DependencyEdgeSourceVolume, volume.ConfigMap.Name, volume.ConfigMap.Optional != nil && *volume.ConfigMap.Optional))
+		case volume.Secret != nil:
+			*edges = append(*edges, secretEdge(pod, "", volume.Name, DependencyEdgeSourceVolume, volume.Secret.SecretName, volume.Secret.Optional != nil && *volume.Secret.Optional))
+		case volume.Projected != nil:
+			for _, s := range volume.Projected.Sources {
+				if s.ConfigMap != nil {
+					*edges = append(*edges, configMapEdge(pod, "", volume.Name, DependencyEdgeSourceVolume, s.ConfigMap.Name, s.ConfigMap.Optional != nil && *s.ConfigMap.Optional))
+				}
+				if s.Secret != nil {
+					*edges = append(*edges, secretEdge(pod, "", volume.Name, DependencyEdgeSourceVolume, s.Secret.Name, s.Secret.Optional != nil && *s.Secret.Optional))
+				}
+			}
+		case volume.CSI != nil:
+			if volume.CSI.NodePublishSecretRef != nil && volume.CSI.NodePublishSecretRef.Name != "" {
+				*edges = append(*edges, secretEdge(pod, "", volume.Name, DependencyEdgeSourceVolume, volume.CSI.NodePublishSecretRef.Name, false))
+			}
+		case volume.HostPath != nil:
+			*edges = append(*edges, DependencyEdge{Volume: volume.Name, Source: DependencyEdgeSourceVolume, Local: true})
+		case volume.DownwardAPI != nil:
+			*edges = append(*edges, DependencyEdge{Volume: volume.Name, Source: DependencyEdgeSourceVolume, Local: true})
+		}
+	}
+}
+
+func visitPodEnvFromEdges(pod *corev1.Pod, edges *[]DependencyEdge) {
+	visitContainersWithName(pod, func(containerName string, envFrom []corev1.EnvFromSource) {
+		for _, ef := range envFrom {
+			if ef.ConfigMapRef != nil && ef.ConfigMapRef.Name != "" {
+				*edges = append(*edges, configMapEdge(pod, containerName, "", DependencyEdgeSourceEnvFrom, ef.ConfigMapRef.Name, ef.ConfigMapRef.Optional != nil && *ef.ConfigMapRef.Optional))
+			}
+			if ef.SecretRef != nil && ef.SecretRef.Name != "" {
+				*edges = append(*edges, secretEdge(pod, containerName, "", DependencyEdgeSourceEnvFrom, ef.SecretRef.Name, ef.SecretRef.Optional != nil && *ef.SecretRef.Optional))
+			}
+		}
+	})
+}
+
+func visitPodEnvEdges(pod *corev1.Pod, edges *[]DependencyEdge) {
+	visitContainersWithNameEnv(pod, func(containerName string, env []corev1.EnvVar) {
+		for _, e := range env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if ref := e.ValueFrom.ConfigMapKeyRef; ref != nil && ref.Name != "" {
+				*edges = append(*edges, configMapEdge(pod, containerName, "", DependencyEdgeSourceEnv, ref.Name, ref.Optional != nil && *ref.Optional))
+			}
+			if ref := e.ValueFrom.SecretKeyRef; ref != nil && ref.Name != "" {
+				*edges = append(*edges, secretEdge(pod, containerName, "", DependencyEdgeSourceEnv, ref.Name, ref.Optional != nil && *ref.Optional))
+			}
+		}
+	})
+}
+
+func visitContainersWithName(pod *corev1.Pod, visitor func(containerName string, envFrom []corev1.EnvFromSource)) {
+	for i := range pod.Spec.InitContainers {
+		visitor(pod.Spec.InitContainers[i].Name, pod.Spec.InitContainers[i].EnvFrom)
+	}
+	for i := range pod.Spec.Containers {
+		visitor(pod.Spec.Containers[i].Name, pod.Spec.Containers[i].EnvFrom)
+	}
+	for i := range pod.Spec.EphemeralContainers {
+		visitor(pod.Spec.EphemeralContainers[i].Name, pod.Spec.EphemeralContainers[i].EnvFrom)
+	}
+}
+
+func visitContainersWithNameEnv(pod *corev1.Pod, visitor func(containerName string, env []corev1.EnvVar)) {
+	for i := range pod.Spec.InitContainers {
+		visitor(pod.Spec.InitContainers[i].Name, pod.Spec.InitContainers[i].Env)
+	}
+	for i := range pod.Spec.Containers {
+		visitor(pod.Spec.Containers[i].Name, pod.Spec.Containers[i].Env)
+	}
+	for i := range pod.Spec.EphemeralContainers {
+		visitor(pod.Spec.EphemeralContainers[i].Name, pod.Spec.EphemeralContainers[i].Env)
+	}
+}
@@ -0,0 +1,131 @@
+package helper
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/karmada-io/karmada/pkg/apis/config/v1alpha1"
+)
+
+func optionalPtr(b bool) *bool { return &b }
+
+func testPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					EnvFrom: []corev1.EnvFromSource{
+						{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "required-cm"}}},
+						{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "optional-secret"}, Optional: optionalPtr(true)}},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetDependencyGraphFromPodTemplate_EdgeMetadata(t *testing.T) {
+	graph, err := GetDependencyGraphFromPodTemplate(testPod())
+	if err != nil {
+		t.Fatalf("GetDependencyGraphFromPodTemplate() error = %v", err)
+	}
+
+	var foundRequiredCM, foundOptionalSecret, foundHostPath bool
+	for _, edge := range graph.Edges {
+		switch {
+		case edge.Reference.Kind == "ConfigMap" && edge.Reference.Name == "required-cm":
+			foundRequiredCM = true
+			if edge.Container != "main" || edge.Source != DependencyEdgeSourceEnvFrom || edge.Optional {
+				t.Errorf("required-cm edge = %+v, want Container=main Source=EnvFrom Optional=false", edge)
+			}
+		case edge.Reference.Kind == "Secret" && edge.Reference.Name == "optional-secret":
+			foundOptionalSecret = true
+			if !edge.Optional {
+				t.Errorf("optional-secret edge = %+v, want Optional=true", edge)
+			}
+		case edge.Volume == "data":
+			foundHostPath = true
+			if !edge.Local || edge.Reference != (configv1alpha1.DependentObjectReference{}) {
+				t.Errorf("hostPath edge = %+v, want Local=true and a zero Reference", edge)
+			}
+		}
+	}
+	if !foundRequiredCM || !foundOptionalSecret || !foundHostPath {
+		t.Fatalf("missing expected edges in %+v", graph.Edges)
+	}
+
+	refs := graph.References()
+	for _, ref := range refs {
+		if ref.Kind == "" {
+			t.Errorf("References() leaked a local edge with a zero Reference: %+v", refs)
+		}
+	}
+}
+
+func TestDependencyGraph_Resolve_PermissiveOptional(t *testing.T) {
+	graph, err := GetDependencyGraphFromPodTemplate(testPod())
+	if err != nil {
+		t.Fatalf("GetDependencyGraphFromPodTemplate() error = %v", err)
+	}
+
+	refs, err := graph.Resolve(DependencyModePermissiveOptional, DependencyOwner{}, nil)
+	if err != nil {
+		t.Fatalf("Resolve(PermissiveOptional) error = %v", err)
+	}
+	for _, ref := range refs {
+		if ref.Name == "optional-secret" {
+			t.Errorf("Resolve(PermissiveOptional) = %+v, should have dropped the optional secret", refs)
+		}
+	}
+}
+
+func TestDependencyGraph_Resolve_Strict(t *testing.T) {
+	graph, err := GetDependencyGraphFromPodTemplate(testPod())
+	if err != nil {
+		t.Fatalf("GetDependencyGraphFromPodTemplate() error = %v", err)
+	}
+
+	checker := func(ref configv1alpha1.DependentObjectReference) (bool, error) {
+		return ref.Name != "required-cm", nil
+	}
+	owner := DependencyOwner{Kind: "Deployment", Name: "my-deploy"}
+	_, err = graph.Resolve(DependencyModeStrict, owner, checker)
+	if err == nil {
+		t.Fatal("Resolve(Strict) error = nil, want an error naming the missing required-cm")
+	}
+	wantMsg := fmt.Sprintf("ConfigMap %s/required-cm is required by Deployment my-deploy but not found", "test")
+	if err.Error() != wantMsg {
+		t.Errorf("Resolve(Strict) error = %q, want %q", err.Error(), wantMsg)
+	}
+
+	_, err = graph.Resolve(DependencyModeStrict, DependencyOwner{}, checker)
+	if err == nil {
+		t.Fatal("Resolve(Strict) error = nil, want an error naming the missing required-cm")
+	}
+	wantMsgNoOwner := fmt.Sprintf("ConfigMap %s/required-cm is required but not found", "test")
+	if err.Error() != wantMsgNoOwner {
+		t.Errorf("Resolve(Strict) with a zero-value owner error = %q, want %q", err.Error(), wantMsgNoOwner)
+	}
+
+	allFoundChecker := func(configv1alpha1.DependentObjectReference) (bool, error) { return true, nil }
+	refs, err := graph.Resolve(DependencyModeStrict, owner, allFoundChecker)
+	if err != nil {
+		t.Fatalf("Resolve(Strict) with everything present error = %v", err)
+	}
+	if len(refs) != len(graph.References()) {
+		t.Errorf("Resolve(Strict) = %v, want it to match References() when nothing is missing", refs)
+	}
+}
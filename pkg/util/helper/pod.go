@@ -6,7 +6,6 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	configv1alpha1 "github.com/karmada-io/karmada/pkg/apis/config/v1alpha1"
-	"github.com/karmada-io/karmada/pkg/util/lifted"
 )
 
 // GetPodCondition extracts the provided condition from the given status and returns that.
@@ -45,60 +44,141 @@ func GeneratePodFromTemplateAndNamespace(template *corev1.PodTemplateSpec, names
 	return pod
 }
 
+// ApplyPodTemplateDefaults merges the cluster-admin-defined defaults into a copy of template and
+// returns it, leaving template itself untouched. Values already set on template always win over
+// defaults: Tolerations, TopologySpreadConstraints, ImagePullSecrets and Env are appended to
+// template's existing entries (skipping any that are already present), NodeSelector keys are
+// added only if not already set, and SecurityContext/PriorityClassName are applied only if
+// template leaves them unset. It should be called by the detector before dependency extraction,
+// so that defaults-injected imagePullSecrets also participate in dependency discovery.
+func ApplyPodTemplateDefaults(template *corev1.PodTemplateSpec, defaults *configv1alpha1.ClusterPodTemplateDefaultsSpec) *corev1.PodTemplateSpec {
+	result := template.DeepCopy()
+	if defaults == nil {
+		return result
+	}
+
+	result.Spec.Tolerations = appendMissingTolerations(result.Spec.Tolerations, defaults.Tolerations)
+	result.Spec.TopologySpreadConstraints = appendMissingTopologySpreadConstraints(result.Spec.TopologySpreadConstraints, defaults.TopologySpreadConstraints)
+	result.Spec.ImagePullSecrets = appendMissingLocalObjectReferences(result.Spec.ImagePullSecrets, defaults.ImagePullSecrets)
+
+	if len(defaults.NodeSelector) > 0 {
+		if result.Spec.NodeSelector == nil {
+			result.Spec.NodeSelector = make(map[string]string, len(defaults.NodeSelector))
+		}
+		for key, value := range defaults.NodeSelector {
+			if _, exists := result.Spec.NodeSelector[key]; !exists {
+				result.Spec.NodeSelector[key] = value
+			}
+		}
+	}
+
+	if result.Spec.SecurityContext == nil && defaults.SecurityContext != nil {
+		result.Spec.SecurityContext = defaults.SecurityContext.DeepCopy()
+	}
+
+	if result.Spec.PriorityClassName == "" {
+		result.Spec.PriorityClassName = defaults.PriorityClassName
+	}
+
+	for i := range result.Spec.Containers {
+		result.Spec.Containers[i].Env = appendMissingEnvVars(result.Spec.Containers[i].Env, defaults.Env)
+	}
+	for i := range result.Spec.InitContainers {
+		result.Spec.InitContainers[i].Env = appendMissingEnvVars(result.Spec.InitContainers[i].Env, defaults.Env)
+	}
+
+	return result
+}
+
+func appendMissingTolerations(existing, defaults []corev1.Toleration) []corev1.Toleration {
+	for _, toleration := range defaults {
+		found := false
+		for _, e := range existing {
+			if tolerationsEqual(e, toleration) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, toleration)
+		}
+	}
+	return existing
+}
+
+// tolerationsEqual compares two Tolerations by value. A plain == would compare TolerationSeconds
+// by pointer identity, so two independently-allocated pointers to the same grace period (the
+// common case for NoExecute tolerations) would never be considered equal.
+func tolerationsEqual(a, b corev1.Toleration) bool {
+	if a.Key != b.Key || a.Operator != b.Operator || a.Value != b.Value || a.Effect != b.Effect {
+		return false
+	}
+	if (a.TolerationSeconds == nil) != (b.TolerationSeconds == nil) {
+		return false
+	}
+	return a.TolerationSeconds == nil || *a.TolerationSeconds == *b.TolerationSeconds
+}
+
+func appendMissingTopologySpreadConstraints(existing, defaults []corev1.TopologySpreadConstraint) []corev1.TopologySpreadConstraint {
+	for _, constraint := range defaults {
+		found := false
+		for _, e := range existing {
+			if e.TopologyKey == constraint.TopologyKey {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, *constraint.DeepCopy())
+		}
+	}
+	return existing
+}
+
+func appendMissingLocalObjectReferences(existing, defaults []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	for _, ref := range defaults {
+		found := false
+		for _, e := range existing {
+			if e.Name == ref.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, ref)
+		}
+	}
+	return existing
+}
+
+func appendMissingEnvVars(existing, defaults []corev1.EnvVar) []corev1.EnvVar {
+	for _, env := range defaults {
+		found := false
+		for _, e := range existing {
+			if e.Name == env.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, env)
+		}
+	}
+	return existing
+}
+
 // GetDependenciesFromPodTemplate extracts the dependencies from the given pod and returns that.
 // returns DependentObjectReferences according to the pod, including ConfigMap, Secret, ServiceAccount and PersistentVolumeClaim.
+// It is a thin wrapper around GetDependencyGraphFromPodTemplate, which is the only place this
+// package walks a pod spec for dependencies; callers that need to know which container/volume
+// caused a reference, or want to resolve optional references with a DependencyMode, should call
+// GetDependencyGraphFromPodTemplate directly instead of duplicating this traversal.
 func GetDependenciesFromPodTemplate(podObj *corev1.Pod) ([]configv1alpha1.DependentObjectReference, error) {
-	dependentConfigMaps := getConfigMapNames(podObj)
-	dependentSecrets := getSecretNames(podObj)
-	dependentSas := getServiceAccountNames(podObj)
-	dependentPVCs := getPVCNames(podObj)
-	var dependentObjectRefs []configv1alpha1.DependentObjectReference
-	for cm := range dependentConfigMaps {
-		dependentObjectRefs = append(dependentObjectRefs, configv1alpha1.DependentObjectReference{
-			APIVersion: "v1",
-			Kind:       "ConfigMap",
-			Namespace:  podObj.Namespace,
-			Name:       cm,
-		})
-	}
-
-	for secret := range dependentSecrets {
-		dependentObjectRefs = append(dependentObjectRefs, configv1alpha1.DependentObjectReference{
-			APIVersion: "v1",
-			Kind:       "Secret",
-			Namespace:  podObj.Namespace,
-			Name:       secret,
-		})
-	}
-
-	for sa := range dependentSas {
-		dependentObjectRefs = append(dependentObjectRefs, configv1alpha1.DependentObjectReference{
-			APIVersion: "v1",
-			Kind:       "ServiceAccount",
-			Namespace:  podObj.Namespace,
-			Name:       sa,
-		})
-	}
-
-	for pvc := range dependentPVCs {
-		dependentObjectRefs = append(dependentObjectRefs, configv1alpha1.DependentObjectReference{
-			APIVersion: "v1",
-			Kind:       "PersistentVolumeClaim",
-			Namespace:  podObj.Namespace,
-			Name:       pvc,
-		})
-	}
-
-	return dependentObjectRefs, nil
-}
-
-func getSecretNames(pod *corev1.Pod) sets.Set[string] {
-	result := sets.New[string]()
-	lifted.VisitPodSecretNames(pod, func(name string) bool {
-		result.Insert(name)
-		return true
-	})
-	return result
+	graph, err := GetDependencyGraphFromPodTemplate(podObj)
+	if err != nil {
+		return nil, err
+	}
+	return graph.References(), nil
 }
 
 func getServiceAccountNames(pod *corev1.Pod) sets.Set[string] {
@@ -109,15 +189,6 @@ func getServiceAccountNames(pod *corev1.Pod) sets.Set[string] {
 	return result
 }
 
-func getConfigMapNames(pod *corev1.Pod) sets.Set[string] {
-	result := sets.New[string]()
-	lifted.VisitPodConfigmapNames(pod, func(name string) bool {
-		result.Insert(name)
-		return true
-	})
-	return result
-}
-
 func getPVCNames(pod *corev1.Pod) sets.Set[string] {
 	result := sets.New[string]()
 	for i := range pod.Spec.Volumes {
@@ -128,6 +199,15 @@ func getPVCNames(pod *corev1.Pod) sets.Set[string] {
 				result.Insert(claimName)
 			}
 		}
+		// A generic ephemeral volume may declare an existing PVC as its data source; that PVC is
+		// propagated alongside the pod just like a plain PVC. Other data source kinds, such as a
+		// volume snapshot, are not reported.
+		if volume.Ephemeral != nil && volume.Ephemeral.VolumeClaimTemplate != nil {
+			dataSource := volume.Ephemeral.VolumeClaimTemplate.Spec.DataSource
+			if dataSource != nil && dataSource.Kind == "PersistentVolumeClaim" && dataSource.Name != "" {
+				result.Insert(dataSource.Name)
+			}
+		}
 	}
 	return result
 }
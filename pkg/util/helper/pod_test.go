@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPVCNames_EphemeralVolumeDataSource(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "existing-pvc"},
+					},
+				},
+				{
+					Name: "scratch",
+					VolumeSource: corev1.VolumeSource{
+						Ephemeral: &corev1.EphemeralVolumeSource{
+							VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+								Spec: corev1.PersistentVolumeClaimSpec{
+									DataSource: &corev1.TypedLocalObjectReference{
+										Kind: "PersistentVolumeClaim",
+										Name: "clone-source-pvc",
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					// An ephemeral volume with no dataSource shouldn't add anything.
+					Name: "scratch-no-datasource",
+					VolumeSource: corev1.VolumeSource{
+						Ephemeral: &corev1.EphemeralVolumeSource{
+							VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := getPVCNames(pod)
+	want := []string{"existing-pvc", "clone-source-pvc"}
+	for _, name := range want {
+		if !got.Has(name) {
+			t.Errorf("getPVCNames() = %v, want it to contain %q", got.UnsortedList(), name)
+		}
+	}
+	if got.Len() != len(want) {
+		t.Errorf("getPVCNames() = %v, want exactly %v", got.UnsortedList(), want)
+	}
+}